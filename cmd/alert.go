@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+)
+
+// Level represents the severity of an Alert.
+type Level string
+
+// The severity levels an Alert can carry, in increasing order of urgency.
+const (
+	LevelOK       Level = "ok"
+	LevelWarn     Level = "warn"
+	LevelCritical Level = "critical"
+)
+
+// Alert describes a single triggered (or recovered) check on a container.
+// Alerters render it in whatever shape their destination expects.
+type Alert struct {
+	Container        string
+	Check            string
+	Level            Level
+	Value            string
+	Threshold        string
+	Time             time.Time
+	SubjectAddendums []string
+
+	// Recovered and Duration are set by the NotificationRouter on the
+	// synthetic Alert it builds for alerters that don't render recovery
+	// natively (SupportsResolve), so a plain "RECOVERED" message can
+	// still include how long the condition was active.
+	Recovered bool
+	Duration  time.Duration
+}
+
+// Dump renders the alert as a plain text summary, used by alerters that
+// only accept a flat message body.
+func (a *Alert) Dump() string {
+	if a.Recovered {
+		return fmt.Sprintf("RECOVERED: %s: %s is back to normal after %s",
+			a.Container, a.Check, a.Duration.Round(time.Second))
+	}
+
+	return fmt.Sprintf("%s: %s is %s (threshold %s) at %s",
+		a.Container, a.Check, a.Value, a.Threshold, a.Time.Format(time.RFC1123))
+}
+
+// DumpEmail renders the alert as an HTML fragment for email alerters.
+func (a *Alert) DumpEmail() string {
+	if a.Recovered {
+		return fmt.Sprintf("<h3>RECOVERED: %s</h3><p>%s is back to normal after %s</p>",
+			a.Container, a.Check, a.Duration.Round(time.Second))
+	}
+
+	return fmt.Sprintf("<h3>%s</h3><p>%s is %s (threshold %s)</p><p>%s</p>",
+		a.Container, a.Check, a.Value, a.Threshold, a.Time.Format(time.RFC1123))
+}
@@ -0,0 +1,317 @@
+package cmd
+
+import (
+	"crypto/tls"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/gomail.v2"
+
+	"github.com/pkg/errors"
+)
+
+// TLSMode controls how Email connects to its SMTP server.
+type TLSMode string
+
+// The supported TLSMode values.
+const (
+	TLSModeNone     TLSMode = "none"
+	TLSModeSTARTTLS TLSMode = "starttls"
+	TLSModeTLS      TLSMode = "tls"
+)
+
+// Email implements the Alerter interface and sends emails. It keeps a
+// single pooled SMTP connection open for the lifetime of the process, so
+// alerts reuse it instead of paying a full TLS handshake per notification,
+// redialing only on the first send or after the server closes it. It can
+// also batch alerts into a digest instead of sending one email per alert.
+type Email struct {
+	SMTP     string
+	Username string
+	Password string
+	Port     string
+	From     string
+	To       []string
+	Subject  string
+
+	TLSMode            TLSMode
+	InsecureSkipVerify bool
+	LocalName          string
+
+	// DigestInterval, if nonzero, batches alerts: Alert() enqueues and a
+	// background goroutine flushes everything accumulated as one grouped
+	// HTML email every DigestInterval.
+	DigestInterval time.Duration
+
+	initOnce sync.Once
+	dialer   *gomail.Dialer
+	queue    chan *Alert
+	done     chan struct{}
+	wg       sync.WaitGroup
+
+	// connMu guards sender, the pooled SMTP connection reused across
+	// sends so only the first Alert() (or a stale reconnect) pays for a
+	// full handshake.
+	connMu sync.Mutex
+	sender gomail.SendCloser
+
+	// sendFunc defaults to e.send; tests override it to exercise the
+	// digest buffering without a real SMTP connection.
+	sendFunc func(alerts []*Alert) error
+}
+
+func (e *Email) isEmpty() bool {
+	return e.SMTP == "" && e.Username == "" && e.Password == "" && e.Port == "" &&
+		e.From == "" && len(e.To) == 0 && e.Subject == ""
+}
+
+// Valid returns true if the email settings are complete
+func (e *Email) Valid() error {
+	errString := []string{}
+
+	if e.isEmpty() {
+		return nil // assume that email alerts were omitted
+	}
+
+	if e.SMTP == "" {
+		errString = append(errString, ErrEmailNoSMTP.Error())
+	}
+
+	if len(e.To) < 1 {
+		errString = append(errString, ErrEmailNoTo.Error())
+	}
+
+	if e.From == "" {
+		errString = append(errString, ErrEmailNoFrom.Error())
+	}
+
+	if e.Username == "" {
+		errString = append(errString, ErrEmailNoUser.Error())
+	}
+
+	if e.Password == "" {
+		errString = append(errString, ErrEmailNoPass.Error())
+	}
+
+	if e.Port == "" {
+		errString = append(errString, ErrEmailNoPort.Error())
+	}
+
+	if e.Subject == "" {
+		errString = append(errString, ErrEmailNoSubject.Error())
+	}
+
+	if len(errString) == 0 {
+		return nil
+	}
+
+	delimErr := strings.Join(errString, ", ")
+	err := errors.New(delimErr)
+
+	return errors.Wrap(err, "email settings validation fail")
+}
+
+// init lazily builds the dialer and, if digesting is enabled, starts the
+// background flush goroutine. It only runs once per Email.
+func (e *Email) init() {
+	e.initOnce.Do(func() {
+		port, _ := strconv.Atoi(e.Port)
+		d := gomail.NewPlainDialer(e.SMTP, port, e.Username, e.Password)
+		d.LocalName = e.LocalName
+
+		switch e.TLSMode {
+		case TLSModeTLS:
+			d.SSL = true
+		case TLSModeNone:
+			d.TLSConfig = nil
+		default: // TLSModeSTARTTLS, or unset
+			d.TLSConfig = &tls.Config{ServerName: e.SMTP}
+		}
+
+		if e.InsecureSkipVerify {
+			if d.TLSConfig == nil {
+				d.TLSConfig = &tls.Config{}
+			}
+			d.TLSConfig.InsecureSkipVerify = true
+		}
+
+		e.dialer = d
+
+		if e.sendFunc == nil {
+			e.sendFunc = e.send
+		}
+
+		if e.DigestInterval > 0 {
+			e.queue = make(chan *Alert, 256)
+			e.done = make(chan struct{})
+			e.wg.Add(1)
+			go e.runDigest()
+		}
+	})
+}
+
+// Alert sends an email alert, or enqueues it for the next digest flush if
+// DigestInterval is set.
+func (e *Email) Alert(a *Alert) error {
+	e.init()
+
+	if e.DigestInterval > 0 {
+		e.queue <- a
+		return nil
+	}
+
+	return e.sendFunc([]*Alert{a})
+}
+
+// Close stops the digest goroutine, flushing any alerts it is still
+// holding, waits for it to finish, and closes the pooled SMTP connection.
+func (e *Email) Close() error {
+	if e.done != nil {
+		close(e.done)
+		e.wg.Wait()
+	}
+
+	e.connMu.Lock()
+	defer e.connMu.Unlock()
+
+	if e.sender == nil {
+		return nil
+	}
+
+	err := e.sender.Close()
+	e.sender = nil
+	return err
+}
+
+func (e *Email) runDigest() {
+	defer e.wg.Done()
+
+	ticker := time.NewTicker(e.DigestInterval)
+	defer ticker.Stop()
+
+	buffer := []*Alert{}
+
+	flush := func() {
+		if len(buffer) == 0 {
+			return
+		}
+		if err := e.sendFunc(buffer); err != nil {
+			log.Printf("error sending email digest: %s", err)
+		}
+		buffer = nil
+	}
+
+	for {
+		select {
+		case a := <-e.queue:
+			buffer = append(buffer, a)
+		case <-ticker.C:
+			flush()
+		case <-e.done:
+			// drain whatever is already queued before flushing and exiting
+			for {
+				select {
+				case a := <-e.queue:
+					buffer = append(buffer, a)
+					continue
+				default:
+				}
+				break
+			}
+			flush()
+			return
+		}
+	}
+}
+
+// send builds one message per alert (or one combined digest message when
+// there is more than one) and delivers them over the pooled SMTP
+// connection, dialing it on first use and redialing once if it has gone
+// stale.
+func (e *Email) send(alerts []*Alert) error {
+	var messages []*gomail.Message
+	if len(alerts) == 1 {
+		messages = []*gomail.Message{e.message(e.subjectFor(alerts[0]), alerts[0].DumpEmail())}
+	} else {
+		var body strings.Builder
+		for _, a := range alerts {
+			body.WriteString(a.DumpEmail())
+		}
+		subject := fmt.Sprintf("%s: %d alerts", e.Subject, len(alerts))
+		messages = []*gomail.Message{e.message(subject, body.String())}
+	}
+
+	e.connMu.Lock()
+	defer e.connMu.Unlock()
+
+	if err := e.sendOnPooledConn(messages); err != nil {
+		return err
+	}
+
+	log.Println("alert email sent")
+	return nil
+}
+
+// sendOnPooledConn sends over e.sender, the pooled SMTP connection,
+// dialing it if this is the first send and redialing once if the server
+// has since closed it (e.g. its own idle timeout). Callers must hold
+// connMu.
+func (e *Email) sendOnPooledConn(messages []*gomail.Message) error {
+	if e.sender == nil {
+		sender, err := e.dialer.Dial()
+		if err != nil {
+			return errors.Wrap(err, "dialing SMTP server")
+		}
+		e.sender = sender
+	}
+
+	if err := gomail.Send(e.sender, messages...); err != nil {
+		e.sender.Close()
+		e.sender = nil
+
+		sender, dialErr := e.dialer.Dial()
+		if dialErr != nil {
+			return errors.Wrap(dialErr, "redialing SMTP server")
+		}
+		e.sender = sender
+
+		if err := gomail.Send(e.sender, messages...); err != nil {
+			e.sender.Close()
+			e.sender = nil
+			return errors.Wrap(err, "error sending email")
+		}
+	}
+
+	return nil
+}
+
+func (e *Email) subjectFor(a *Alert) string {
+	subject := e.Subject + ": "
+	for i := range a.SubjectAddendums {
+		// add addendums to the subject
+		subject += fmt.Sprintf("%s ", a.SubjectAddendums[i])
+		if i == 2 { // subjects cannot be too long, stop if it is at position 3
+			subject += fmt.Sprintf("...")
+			break
+		}
+	}
+	return subject
+}
+
+func (e *Email) message(subject, htmlBody string) *gomail.Message {
+	to := strings.Join(e.To, ",")
+
+	m := gomail.NewMessage()
+	m.SetBody("text/html", htmlBody)
+	m.SetHeaders(map[string][]string{
+		"From":    {e.From},
+		"To":      {to},
+		"Subject": {subject},
+	})
+
+	return m
+}
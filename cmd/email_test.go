@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestEmail_DigestBatchesMultipleAlertsIntoOneSend(t *testing.T) {
+	var mu sync.Mutex
+	var batches [][]*Alert
+
+	e := &Email{
+		Subject:        "docker-alertd",
+		DigestInterval: 20 * time.Millisecond,
+		sendFunc: func(alerts []*Alert) error {
+			mu.Lock()
+			defer mu.Unlock()
+			batches = append(batches, alerts)
+			return nil
+		},
+	}
+	defer e.Close()
+
+	e.Alert(&Alert{Container: "web", Check: "cpu"})
+	e.Alert(&Alert{Container: "web", Check: "memory"})
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		mu.Lock()
+		n := len(batches)
+		mu.Unlock()
+		if n > 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for digest flush")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(batches) != 1 {
+		t.Fatalf("expected a single flushed batch, got %d", len(batches))
+	}
+	if len(batches[0]) != 2 {
+		t.Fatalf("expected both alerts in one batch, got %d", len(batches[0]))
+	}
+}
+
+func TestEmail_CloseFlushesPendingAlertsBeforeStopping(t *testing.T) {
+	var mu sync.Mutex
+	var batches [][]*Alert
+
+	e := &Email{
+		Subject:        "docker-alertd",
+		DigestInterval: time.Hour, // long enough that only Close should trigger the flush
+		sendFunc: func(alerts []*Alert) error {
+			mu.Lock()
+			defer mu.Unlock()
+			batches = append(batches, alerts)
+			return nil
+		},
+	}
+
+	e.Alert(&Alert{Container: "web", Check: "cpu"})
+
+	if err := e.Close(); err != nil {
+		t.Fatalf("Close returned error: %s", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(batches) != 1 || len(batches[0]) != 1 {
+		t.Fatalf("expected Close to flush the single pending alert, got batches=%v", batches)
+	}
+}
+
+func TestEmail_NonDigestSendsImmediatelyPerAlert(t *testing.T) {
+	var mu sync.Mutex
+	var batches [][]*Alert
+
+	e := &Email{
+		Subject: "docker-alertd",
+		sendFunc: func(alerts []*Alert) error {
+			mu.Lock()
+			defer mu.Unlock()
+			batches = append(batches, alerts)
+			return nil
+		},
+	}
+
+	e.Alert(&Alert{Container: "web", Check: "cpu"})
+	e.Alert(&Alert{Container: "web", Check: "memory"})
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(batches) != 2 {
+		t.Fatalf("expected one send per alert without DigestInterval, got %d", len(batches))
+	}
+}
+
+func TestEmail_IsEmptyTreatsZeroValueAsOmitted(t *testing.T) {
+	if err := (&Email{}).Valid(); err != nil {
+		t.Fatalf("expected a zero-value Email to validate as omitted, got %s", err)
+	}
+}
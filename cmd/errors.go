@@ -0,0 +1,18 @@
+package cmd
+
+import "github.com/pkg/errors"
+
+// Validation errors surfaced by an Alerter's Valid method.
+var (
+	ErrEmailNoSMTP       = errors.New("no SMTP host configured")
+	ErrEmailNoTo         = errors.New("no To addresses configured")
+	ErrEmailNoFrom       = errors.New("no From address configured")
+	ErrEmailNoUser       = errors.New("no username configured")
+	ErrEmailNoPass       = errors.New("no password configured")
+	ErrEmailNoPort       = errors.New("no port configured")
+	ErrEmailNoSubject    = errors.New("no subject configured")
+	ErrSlackNoWebHookURL = errors.New("no webhook URL configured")
+	ErrPushoverAPIToken  = errors.New("no API token configured")
+	ErrPushoverUserKey   = errors.New("no user key configured")
+	ErrPushoverAPIURL    = errors.New("no API URL configured")
+)
@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// pagerDutyEventsURL is PagerDuty's Events API v2 enqueue endpoint. It's a
+// var, not a const, so tests can point it at an httptest.Server.
+var pagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+// pagerDutyRateLimit and pagerDutyRateWindow cap requests at PagerDuty's 120
+// events/minute. They're vars, not consts, so tests can shrink them instead
+// of waiting out a real minute to exercise the throttle delay path.
+var (
+	pagerDutyRateLimit  = 120
+	pagerDutyRateWindow = time.Minute
+)
+
+// PagerDuty implements the Alerter interface and sends alerts to PagerDuty's
+// Events API v2, triggering an incident per (container, check) and
+// automatically resolving it once the condition clears.
+type PagerDuty struct {
+	RoutingKey string
+
+	client *http.Client
+
+	mu              sync.Mutex
+	sentByContainer map[string][]time.Time
+	state           map[string]Level
+}
+
+// Valid returns an error if the PagerDuty settings are invalid.
+func (p *PagerDuty) Valid() error {
+	if p.RoutingKey == "" {
+		return errors.New("pagerduty settings validation fail: no routing key configured")
+	}
+
+	return nil
+}
+
+// Close releases the PagerDuty alerter's pooled HTTP client.
+func (p *PagerDuty) Close() error {
+	if p.client != nil {
+		p.client.CloseIdleConnections()
+	}
+	return nil
+}
+
+// SupportsResolve reports that PagerDuty renders recovery natively: the
+// NotificationRouter passes it the original Alert, whose Alert method
+// already emits a "resolve" event for the matching dedup_key.
+func (p *PagerDuty) SupportsResolve() bool {
+	return true
+}
+
+// dedupKey derives a stable PagerDuty dedup_key from the container and
+// check, so repeated alerts for the same problem collapse into one
+// incident instead of opening a new one each time.
+func dedupKey(a *Alert) string {
+	return fmt.Sprintf("docker-alertd:%s:%s", a.Container, a.Check)
+}
+
+// pagerDutySeverity maps an Alert's Level to an Events v2 severity.
+func pagerDutySeverity(l Level) string {
+	switch l {
+	case LevelCritical:
+		return "critical"
+	case LevelWarn:
+		return "warning"
+	default:
+		return "info"
+	}
+}
+
+type pagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"`
+	DedupKey    string            `json:"dedup_key"`
+	Payload     *pagerDutyPayload `json:"payload,omitempty"`
+}
+
+type pagerDutyPayload struct {
+	Summary       string                 `json:"summary"`
+	Severity      string                 `json:"severity"`
+	Source        string                 `json:"source"`
+	Component     string                 `json:"component"`
+	Class         string                 `json:"class"`
+	CustomDetails map[string]interface{} `json:"custom_details"`
+}
+
+// Alert sends a trigger event to PagerDuty, or a resolve event for the same
+// dedup_key once the alert's level returns to LevelOK.
+func (p *PagerDuty) Alert(a *Alert) error {
+	key := dedupKey(a)
+
+	p.mu.Lock()
+	if p.state == nil {
+		p.state = map[string]Level{}
+	}
+	wasBad := p.state[key] != "" && p.state[key] != LevelOK
+	p.state[key] = a.Level
+	p.mu.Unlock()
+
+	if a.Level == LevelOK {
+		if !wasBad {
+			return nil // nothing to resolve
+		}
+		return p.send(a.Container, pagerDutyEvent{
+			RoutingKey:  p.RoutingKey,
+			EventAction: "resolve",
+			DedupKey:    key,
+		})
+	}
+
+	hostname, _ := os.Hostname()
+
+	return p.send(a.Container, pagerDutyEvent{
+		RoutingKey:  p.RoutingKey,
+		EventAction: "trigger",
+		DedupKey:    key,
+		Payload: &pagerDutyPayload{
+			Summary:   a.Dump(),
+			Severity:  pagerDutySeverity(a.Level),
+			Source:    hostname,
+			Component: a.Container,
+			Class:     a.Check,
+			CustomDetails: map[string]interface{}{
+				"value":     a.Value,
+				"threshold": a.Threshold,
+				"time":      a.Time,
+			},
+		},
+	})
+}
+
+// send posts event to PagerDuty, honouring the per-container rate limit and
+// the 429 Retry-After header.
+func (p *PagerDuty) send(container string, event pagerDutyEvent) error {
+	p.throttle(container)
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return errors.Wrap(err, "encoding pagerduty event")
+	}
+
+	client := p.client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	const maxAttempts = 5
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		resp, err := client.Post(pagerDutyEventsURL, "application/json", bytes.NewReader(body))
+		if err != nil {
+			return errors.Wrap(err, "posting to pagerduty")
+		}
+
+		if resp.StatusCode == http.StatusTooManyRequests {
+			resp.Body.Close()
+			time.Sleep(retryAfter(resp))
+			continue
+		}
+
+		respBody, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusAccepted {
+			return errors.Errorf("pagerduty returned %s: %s", resp.Status, respBody)
+		}
+
+		return nil
+	}
+
+	return errors.New("giving up on pagerduty after repeated 429 responses")
+}
+
+// throttle blocks, delaying rather than dropping the event, until this
+// container's send history is back under the Events API's 120
+// events/minute cap. The limit is tracked per container so a burst on one
+// container doesn't delay alerts for another.
+func (p *PagerDuty) throttle(container string) {
+	for {
+		p.mu.Lock()
+
+		if p.sentByContainer == nil {
+			p.sentByContainer = map[string][]time.Time{}
+		}
+
+		now := time.Now()
+		cutoff := now.Add(-pagerDutyRateWindow)
+
+		times := p.sentByContainer[container]
+		kept := times[:0]
+		for _, t := range times {
+			if t.After(cutoff) {
+				kept = append(kept, t)
+			}
+		}
+
+		if len(kept) < pagerDutyRateLimit {
+			p.sentByContainer[container] = append(kept, now)
+			p.mu.Unlock()
+			return
+		}
+
+		wait := kept[0].Add(pagerDutyRateWindow).Sub(now)
+		p.sentByContainer[container] = kept
+		p.mu.Unlock()
+
+		if wait > 0 {
+			time.Sleep(wait)
+		}
+	}
+}
+
+// retryAfter parses PagerDuty's Retry-After header, falling back to a
+// conservative default if it is missing or malformed.
+func retryAfter(resp *http.Response) time.Duration {
+	if s := resp.Header.Get("Retry-After"); s != "" {
+		if secs, err := strconv.Atoi(s); err == nil {
+			return time.Duration(secs) * time.Second
+		}
+	}
+	return time.Second
+}
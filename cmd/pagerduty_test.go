@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// withPagerDutyRateLimit shrinks the rate limit/window for the duration of
+// fn, so tests can exercise the throttle delay path without waiting out a
+// real minute.
+func withPagerDutyRateLimit(t *testing.T, limit int, window time.Duration, fn func()) {
+	t.Helper()
+
+	originalLimit, originalWindow := pagerDutyRateLimit, pagerDutyRateWindow
+	pagerDutyRateLimit, pagerDutyRateWindow = limit, window
+	defer func() { pagerDutyRateLimit, pagerDutyRateWindow = originalLimit, originalWindow }()
+
+	fn()
+}
+
+// withPagerDutyTestServer points pagerDutyEventsURL at a test server for
+// the duration of fn, recording every decoded event it receives.
+func withPagerDutyTestServer(t *testing.T, fn func(events *[]pagerDutyEvent)) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var events []pagerDutyEvent
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event pagerDutyEvent
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			t.Errorf("decoding pagerduty event: %s", err)
+		}
+
+		mu.Lock()
+		events = append(events, event)
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"status":"success"}`))
+	}))
+	defer server.Close()
+
+	original := pagerDutyEventsURL
+	pagerDutyEventsURL = server.URL
+	defer func() { pagerDutyEventsURL = original }()
+
+	fn(&events)
+}
+
+func TestPagerDuty_TriggersOnBadAlert(t *testing.T) {
+	withPagerDutyTestServer(t, func(events *[]pagerDutyEvent) {
+		p := &PagerDuty{RoutingKey: "test-key"}
+
+		err := p.Alert(&Alert{Container: "web", Check: "cpu", Level: LevelCritical, Value: "95%", Threshold: "90%"})
+		if err != nil {
+			t.Fatalf("Alert returned error: %s", err)
+		}
+
+		if len(*events) != 1 {
+			t.Fatalf("expected 1 event, got %d", len(*events))
+		}
+		if (*events)[0].EventAction != "trigger" {
+			t.Fatalf("expected a trigger event, got %q", (*events)[0].EventAction)
+		}
+	})
+}
+
+func TestPagerDuty_ResolvesOnceConditionClears(t *testing.T) {
+	withPagerDutyTestServer(t, func(events *[]pagerDutyEvent) {
+		p := &PagerDuty{RoutingKey: "test-key"}
+
+		p.Alert(&Alert{Container: "web", Check: "cpu", Level: LevelCritical})
+		p.Alert(&Alert{Container: "web", Check: "cpu", Level: LevelOK})
+
+		if len(*events) != 2 {
+			t.Fatalf("expected trigger + resolve, got %d events", len(*events))
+		}
+		if (*events)[1].EventAction != "resolve" {
+			t.Fatalf("expected a resolve event, got %q", (*events)[1].EventAction)
+		}
+		if (*events)[1].DedupKey != (*events)[0].DedupKey {
+			t.Fatalf("expected resolve to reuse the trigger's dedup_key: trigger=%q resolve=%q",
+				(*events)[0].DedupKey, (*events)[1].DedupKey)
+		}
+	})
+}
+
+func TestPagerDuty_NoResolveWithoutAPriorTrigger(t *testing.T) {
+	withPagerDutyTestServer(t, func(events *[]pagerDutyEvent) {
+		p := &PagerDuty{RoutingKey: "test-key"}
+
+		p.Alert(&Alert{Container: "web", Check: "cpu", Level: LevelOK})
+
+		if len(*events) != 0 {
+			t.Fatalf("expected no event for an already-ok check, got %d", len(*events))
+		}
+	})
+}
+
+func TestPagerDuty_ThrottleDelaysOnceRateLimitExceeded(t *testing.T) {
+	withPagerDutyRateLimit(t, 2, 100*time.Millisecond, func() {
+		p := &PagerDuty{}
+
+		p.throttle("web")
+		p.throttle("web")
+
+		start := time.Now()
+		p.throttle("web")
+		elapsed := time.Since(start)
+
+		if elapsed < 50*time.Millisecond {
+			t.Fatalf("expected throttle to delay the 3rd send until the window passed, only waited %s", elapsed)
+		}
+	})
+}
+
+func TestPagerDuty_ThrottleIsPerContainer(t *testing.T) {
+	withPagerDutyRateLimit(t, 1, time.Minute, func() {
+		p := &PagerDuty{}
+
+		start := time.Now()
+		p.throttle("web")
+		p.throttle("db") // a different container must not wait on web's budget
+		elapsed := time.Since(start)
+
+		if elapsed > 50*time.Millisecond {
+			t.Fatalf("expected a different container's throttle to not be delayed by web's budget, took %s", elapsed)
+		}
+	})
+}
+
+func TestPagerDuty_DedupKeyIsStablePerContainerAndCheck(t *testing.T) {
+	a := &Alert{Container: "web", Check: "cpu"}
+	b := &Alert{Container: "web", Check: "cpu"}
+	c := &Alert{Container: "web", Check: "memory"}
+
+	if dedupKey(a) != dedupKey(b) {
+		t.Fatalf("expected identical container/check to share a dedup_key")
+	}
+	if dedupKey(a) == dedupKey(c) {
+		t.Fatalf("expected different checks to have different dedup_keys")
+	}
+}
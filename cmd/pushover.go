@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Pushover contains all info needed to push a notification to Pushover api
+type Pushover struct {
+	APIToken string
+	UserKey  string
+	APIURL   string
+
+	Title    string
+	Sound    string
+	Device   string
+	URL      string
+	URLTitle string
+
+	// Priority is Pushover's -2 (lowest) .. 2 (emergency) priority. If
+	// zero, it is derived from the Alert's severity.
+	Priority int
+	// PriorityByCheck overrides Priority for specific check types (e.g.
+	// "running" => 2, "cpu" => 0), keyed the same as Alert.Check. Checks
+	// not listed here fall back to Priority, then to the severity-based
+	// default.
+	PriorityByCheck map[string]int
+	// Retry and Expire are required by Pushover for Priority 2 (emergency)
+	// messages: Retry is how often (seconds) the notification is resent
+	// until acknowledged, Expire is how long (seconds) it keeps retrying.
+	Retry  int
+	Expire int
+}
+
+// Valid returns an error if pushover settings are invalid
+func (p Pushover) Valid() error {
+	errString := []string{}
+
+	if reflect.DeepEqual(Pushover{}, p) {
+		return nil // assume that pushover was omitted
+	}
+
+	if p.APIToken == "" {
+		errString = append(errString, ErrPushoverAPIToken.Error())
+	}
+
+	if p.UserKey == "" {
+		errString = append(errString, ErrPushoverUserKey.Error())
+	}
+
+	if p.APIURL == "" {
+		errString = append(errString, ErrPushoverAPIURL.Error())
+	}
+
+	if p.emergencyReachable() && (p.Retry == 0 || p.Expire == 0) {
+		errString = append(errString, "priority 2 (emergency) requires Retry and Expire")
+	}
+
+	if len(errString) == 0 {
+		return nil
+	}
+
+	delimErr := strings.Join(errString, ", ")
+	err := errors.New(delimErr)
+
+	return errors.Wrap(err, "pushover settings validation fail")
+}
+
+// Close releases any resources held by the Pushover alerter. Pushover posts
+// over plain http.Post today, so there is nothing to release.
+func (p Pushover) Close() error {
+	return nil
+}
+
+// emergencyReachable reports whether priority() can ever return 2 for this
+// configuration, so Valid() can require Retry/Expire up front instead of
+// only failing against Pushover's live API on the first critical alert.
+func (p Pushover) emergencyReachable() bool {
+	for _, pr := range p.PriorityByCheck {
+		if pr == 2 {
+			return true
+		}
+	}
+
+	// Priority 0 means "derive from severity", and that derivation maps
+	// LevelCritical to 2 for any check without its own override.
+	return p.Priority == 2 || p.Priority == 0
+}
+
+// priority returns the priority for a.Check, preferring a per-check
+// override, then the global Priority, then one derived from the alert's
+// severity when both are left at their zero value.
+func (p Pushover) priority(a *Alert) int {
+	if pr, ok := p.PriorityByCheck[a.Check]; ok {
+		return pr
+	}
+
+	if p.Priority != 0 {
+		return p.Priority
+	}
+
+	switch a.Level {
+	case LevelCritical:
+		return 2
+	case LevelWarn:
+		return 0
+	default:
+		return -1
+	}
+}
+
+// Alert sends the alert to Pushover API
+func (p Pushover) Alert(a *Alert) error {
+	values := url.Values{}
+	values.Set("token", p.APIToken)
+	values.Set("user", p.UserKey)
+	values.Set("message", a.Dump())
+
+	title := p.Title
+	if title == "" {
+		title = fmt.Sprintf("docker-alertd: %s", a.Container)
+	}
+	values.Set("title", title)
+
+	if p.Sound != "" {
+		values.Set("sound", p.Sound)
+	}
+	if p.Device != "" {
+		values.Set("device", p.Device)
+	}
+	if p.URL != "" {
+		values.Set("url", p.URL)
+	}
+	if p.URLTitle != "" {
+		values.Set("url_title", p.URLTitle)
+	}
+
+	priority := p.priority(a)
+	values.Set("priority", strconv.Itoa(priority))
+
+	if priority == 2 {
+		values.Set("retry", strconv.Itoa(p.Retry))
+		values.Set("expire", strconv.Itoa(p.Expire))
+	}
+
+	resp, err := http.Post(p.APIURL, "application/x-www-form-urlencoded", strings.NewReader(values.Encode()))
+	if err != nil {
+		return errors.Wrap(err, "sending pushover request")
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "reading pushover response")
+	}
+
+	var result struct {
+		Status  int      `json:"status"`
+		Errors  []string `json:"errors"`
+		Request string   `json:"request"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return errors.Wrap(err, "decoding pushover response")
+	}
+
+	if result.Status != 1 {
+		return errors.Errorf("pushover rejected message: %s", strings.Join(result.Errors, ", "))
+	}
+
+	log.Println("sent alert to pushover")
+	return nil
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestPushover_PriorityPrefersPerCheckOverrideThenGlobalThenSeverity(t *testing.T) {
+	p := Pushover{Priority: 1, PriorityByCheck: map[string]int{"running": 2}}
+
+	if got := p.priority(&Alert{Check: "running", Level: LevelWarn}); got != 2 {
+		t.Fatalf("expected per-check override to win, got %d", got)
+	}
+	if got := p.priority(&Alert{Check: "cpu", Level: LevelWarn}); got != 1 {
+		t.Fatalf("expected global Priority to be used without a per-check override, got %d", got)
+	}
+
+	derived := Pushover{}
+	if got := derived.priority(&Alert{Check: "cpu", Level: LevelCritical}); got != 2 {
+		t.Fatalf("expected severity-derived priority 2 for LevelCritical, got %d", got)
+	}
+	if got := derived.priority(&Alert{Check: "cpu", Level: LevelWarn}); got != 0 {
+		t.Fatalf("expected severity-derived priority 0 for LevelWarn, got %d", got)
+	}
+	if got := derived.priority(&Alert{Check: "cpu", Level: LevelOK}); got != -1 {
+		t.Fatalf("expected severity-derived priority -1 for LevelOK, got %d", got)
+	}
+}
+
+func TestPushover_ValidRequiresRetryExpireWhenEmergencyReachableViaPerCheckOverride(t *testing.T) {
+	p := Pushover{
+		APIToken:        "tok",
+		UserKey:         "user",
+		APIURL:          "http://example.com",
+		PriorityByCheck: map[string]int{"running": 2},
+	}
+
+	if err := p.Valid(); err == nil {
+		t.Fatalf("expected an error: a per-check priority 2 override requires Retry/Expire")
+	}
+
+	p.Retry = 30
+	p.Expire = 3600
+	if err := p.Valid(); err != nil {
+		t.Fatalf("expected Valid to pass once Retry/Expire are set, got %s", err)
+	}
+}
+
+func TestPushover_ValidRequiresRetryExpireForSeverityDerivedEmergency(t *testing.T) {
+	p := Pushover{APIToken: "tok", UserKey: "user", APIURL: "http://example.com"}
+
+	if err := p.Valid(); err == nil {
+		t.Fatalf("expected an error: Priority 0 can still derive emergency priority 2 from LevelCritical")
+	}
+}
+
+func TestPushover_ValidTreatsZeroValueAsOmitted(t *testing.T) {
+	if err := (Pushover{}).Valid(); err != nil {
+		t.Fatalf("expected a zero-value Pushover to validate as omitted, got %s", err)
+	}
+}
+
+func TestPushover_AlertSendsConfiguredFieldsAndDerivedPriority(t *testing.T) {
+	var gotValues url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		gotValues = r.Form
+		json.NewEncoder(w).Encode(map[string]interface{}{"status": 1, "request": "abc"})
+	}))
+	defer server.Close()
+
+	p := Pushover{APIToken: "tok", UserKey: "user", APIURL: server.URL, Retry: 30, Expire: 3600}
+
+	if err := p.Alert(&Alert{Container: "web", Check: "cpu", Level: LevelCritical, Value: "95%", Threshold: "90%"}); err != nil {
+		t.Fatalf("Alert returned error: %s", err)
+	}
+
+	if gotValues.Get("priority") != "2" {
+		t.Fatalf("expected priority 2 for a critical alert, got %q", gotValues.Get("priority"))
+	}
+	if gotValues.Get("retry") != "30" || gotValues.Get("expire") != "3600" {
+		t.Fatalf("expected retry/expire to be sent for an emergency priority, got retry=%q expire=%q",
+			gotValues.Get("retry"), gotValues.Get("expire"))
+	}
+}
+
+func TestPushover_AlertReturnsErrorWhenPushoverRejectsMessage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"status": 0, "errors": []string{"invalid user"}})
+	}))
+	defer server.Close()
+
+	p := Pushover{APIToken: "tok", UserKey: "user", APIURL: server.URL}
+	if err := p.Alert(&Alert{Container: "web", Check: "cpu", Level: LevelWarn}); err == nil {
+		t.Fatalf("expected an error when pushover rejects the message")
+	}
+}
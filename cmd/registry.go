@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// AlerterFactory builds an Alerter from its settings, as decoded from the
+// notifier's config block.
+type AlerterFactory func(settings map[string]interface{}) (Alerter, error)
+
+var alerterRegistry = map[string]AlerterFactory{}
+
+// RegisterAlerter makes an Alerter factory available under name so it can be
+// instantiated by config. Notifier implementations call this from their own
+// init, the same way database/sql drivers register themselves.
+func RegisterAlerter(name string, factory AlerterFactory) {
+	alerterRegistry[name] = factory
+}
+
+// NotifierConfig describes one enabled notifier entry in config: which
+// registered alerter to build, its settings, and the wrapping behavior
+// applied around every Alert() call.
+type NotifierConfig struct {
+	Name         string
+	MinSeverity  Level
+	MaxRetries   int
+	RetryBackoff time.Duration
+	Settings     map[string]interface{}
+}
+
+// LoadAlerters builds the enabled Alerters from config, wrapping each in the
+// shared severity filter and retry behavior.
+func LoadAlerters(configs []NotifierConfig) ([]Alerter, error) {
+	alerters := make([]Alerter, 0, len(configs))
+
+	for _, c := range configs {
+		factory, ok := alerterRegistry[c.Name]
+		if !ok {
+			return nil, errors.Errorf("no alerter registered with name %q", c.Name)
+		}
+
+		a, err := factory(c.Settings)
+		if err != nil {
+			return nil, errors.Wrapf(err, "building alerter %q", c.Name)
+		}
+
+		if err := a.Valid(); err != nil {
+			return nil, errors.Wrapf(err, "alerter %q", c.Name)
+		}
+
+		if c.MinSeverity != "" {
+			a = severityFilter{Alerter: a, min: c.MinSeverity}
+		}
+
+		if c.MaxRetries > 0 {
+			a = retrier{Alerter: a, maxRetries: c.MaxRetries, backoff: c.RetryBackoff}
+		}
+
+		alerters = append(alerters, a)
+	}
+
+	return alerters, nil
+}
+
+// severityLevels orders Level values so a minimum severity can be compared.
+var severityLevels = map[Level]int{
+	LevelOK:       0,
+	LevelWarn:     1,
+	LevelCritical: 2,
+}
+
+// severityFilter wraps an Alerter so it only fires for alerts at or above a
+// minimum severity, e.g. so a pager only rings on warn/critical. Recovery
+// alerts (LevelOK, or a synthesized Recovered alert) always pass through
+// regardless of MinSeverity, since suppressing them would tell on-call
+// about an incident but never that it cleared.
+type severityFilter struct {
+	Alerter
+	min Level
+}
+
+func (s severityFilter) Alert(a *Alert) error {
+	if !a.Recovered && a.Level != LevelOK && severityLevels[a.Level] < severityLevels[s.min] {
+		return nil
+	}
+	return s.Alerter.Alert(a)
+}
+
+// SupportsResolve delegates to the wrapped Alerter. Embedding the Alerter
+// interface only promotes Alerter's own methods, so without this the
+// NotificationRouter's `alerter.(SupportsResolve)` type assertion would
+// fail for any wrapped notifier that implements it.
+func (s severityFilter) SupportsResolve() bool {
+	sr, ok := s.Alerter.(SupportsResolve)
+	return ok && sr.SupportsResolve()
+}
+
+// retrier wraps an Alerter so transient delivery failures are retried with a
+// fixed backoff before giving up.
+type retrier struct {
+	Alerter
+	maxRetries int
+	backoff    time.Duration
+}
+
+func (r retrier) Alert(a *Alert) error {
+	var err error
+	for attempt := 0; attempt <= r.maxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(r.backoff)
+		}
+
+		if err = r.Alerter.Alert(a); err == nil {
+			return nil
+		}
+	}
+
+	return errors.Wrapf(err, "giving up after %d retries", r.maxRetries)
+}
+
+// SupportsResolve delegates to the wrapped Alerter, for the same reason as
+// severityFilter.SupportsResolve above.
+func (r retrier) SupportsResolve() bool {
+	sr, ok := r.Alerter.(SupportsResolve)
+	return ok && sr.SupportsResolve()
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"net/http"
+	"time"
+)
+
+// Alerter is the interface which will handle alerting via different methods such as email
+// and slack
+type Alerter interface {
+	Valid() error
+	Alert(a *Alert) error
+	// Close releases any long-lived resources held by the Alerter, such as
+	// a pooled SMTP connection or HTTP client. Alerters with nothing to
+	// release can return nil.
+	Close() error
+}
+
+func init() {
+	RegisterAlerter("email", func(settings map[string]interface{}) (Alerter, error) {
+		e := &Email{
+			SMTP:               settingString(settings, "smtp"),
+			Username:           settingString(settings, "username"),
+			Password:           settingString(settings, "password"),
+			Port:               settingString(settings, "port"),
+			From:               settingString(settings, "from"),
+			Subject:            settingString(settings, "subject"),
+			TLSMode:            TLSMode(settingString(settings, "tls_mode")),
+			InsecureSkipVerify: settingString(settings, "insecure_skip_verify") == "true",
+			LocalName:          settingString(settings, "local_name"),
+			DigestInterval:     time.Duration(settingInt(settings, "digest_interval_seconds")) * time.Second,
+		}
+		if to, ok := settings["to"].([]string); ok {
+			e.To = to
+		}
+		return e, nil
+	})
+
+	RegisterAlerter("slack", func(settings map[string]interface{}) (Alerter, error) {
+		return Slack{
+			WebhookURL: settingString(settings, "webhook_url"),
+			Channel:    settingString(settings, "channel"),
+			Username:   settingString(settings, "username"),
+			IconEmoji:  settingString(settings, "icon_emoji"),
+			IconURL:    settingString(settings, "icon_url"),
+			Template:   settingString(settings, "template"),
+		}, nil
+	})
+
+	RegisterAlerter("pushover", func(settings map[string]interface{}) (Alerter, error) {
+		p := Pushover{
+			APIToken: settingString(settings, "api_token"),
+			UserKey:  settingString(settings, "user_key"),
+			APIURL:   settingString(settings, "api_url"),
+			Title:    settingString(settings, "title"),
+			Sound:    settingString(settings, "sound"),
+			Device:   settingString(settings, "device"),
+			URL:      settingString(settings, "url"),
+			URLTitle: settingString(settings, "url_title"),
+			Priority: settingInt(settings, "priority"),
+			Retry:    settingInt(settings, "retry"),
+			Expire:   settingInt(settings, "expire"),
+		}
+
+		if byCheck, ok := settings["priority_by_check"].(map[string]interface{}); ok {
+			p.PriorityByCheck = make(map[string]int, len(byCheck))
+			for check := range byCheck {
+				p.PriorityByCheck[check] = settingInt(byCheck, check)
+			}
+		}
+
+		return p, nil
+	})
+
+	RegisterAlerter("pagerduty", func(settings map[string]interface{}) (Alerter, error) {
+		return &PagerDuty{
+			RoutingKey: settingString(settings, "routing_key"),
+			client:     &http.Client{},
+		}, nil
+	})
+}
+
+// settingString pulls a string value out of a notifier's settings map,
+// returning the zero value if the key is absent or of another type.
+func settingString(settings map[string]interface{}, key string) string {
+	s, _ := settings[key].(string)
+	return s
+}
+
+// settingInt pulls an int value out of a notifier's settings map,
+// returning zero if the key is absent or of another type.
+func settingInt(settings map[string]interface{}, key string) int {
+	switch v := settings[key].(type) {
+	case int:
+		return v
+	case float64:
+		return int(v)
+	default:
+		return 0
+	}
+}
+
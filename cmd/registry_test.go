@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// erroringAlerter fails its first `failures` Alert calls, then succeeds, so
+// tests can exercise retrier's retry/give-up behavior.
+type erroringAlerter struct {
+	failures int
+	calls    int
+}
+
+func (e *erroringAlerter) Valid() error { return nil }
+func (e *erroringAlerter) Close() error { return nil }
+func (e *erroringAlerter) Alert(a *Alert) error {
+	e.calls++
+	if e.calls <= e.failures {
+		return errors.New("transient failure")
+	}
+	return nil
+}
+
+func TestRetrier_SucceedsAfterTransientFailures(t *testing.T) {
+	base := &erroringAlerter{failures: 2}
+	r := retrier{Alerter: base, maxRetries: 3, backoff: time.Millisecond}
+
+	if err := r.Alert(&Alert{}); err != nil {
+		t.Fatalf("expected retrier to succeed within maxRetries, got error: %s", err)
+	}
+	if base.calls != 3 {
+		t.Fatalf("expected 3 attempts (1 + 2 retries), got %d", base.calls)
+	}
+}
+
+func TestRetrier_GivesUpAfterMaxRetries(t *testing.T) {
+	base := &erroringAlerter{failures: 10}
+	r := retrier{Alerter: base, maxRetries: 2, backoff: time.Millisecond}
+
+	if err := r.Alert(&Alert{}); err == nil {
+		t.Fatalf("expected retrier to give up and return an error")
+	}
+	if base.calls != 3 {
+		t.Fatalf("expected exactly maxRetries+1 attempts, got %d", base.calls)
+	}
+}
+
+func TestLoadAlerters_WrapsWithSeverityFilterAndRetrier(t *testing.T) {
+	RegisterAlerter("test-registry-alerter", func(settings map[string]interface{}) (Alerter, error) {
+		return &recordingAlerter{}, nil
+	})
+
+	alerters, err := LoadAlerters([]NotifierConfig{
+		{Name: "test-registry-alerter", MinSeverity: LevelWarn, MaxRetries: 2, RetryBackoff: time.Millisecond},
+	})
+	if err != nil {
+		t.Fatalf("LoadAlerters returned error: %s", err)
+	}
+	if len(alerters) != 1 {
+		t.Fatalf("expected 1 alerter, got %d", len(alerters))
+	}
+
+	outer, ok := alerters[0].(retrier)
+	if !ok {
+		t.Fatalf("expected the outermost wrapper to be a retrier")
+	}
+	if _, ok := outer.Alerter.(severityFilter); !ok {
+		t.Fatalf("expected the retrier to wrap a severityFilter")
+	}
+}
+
+func TestLoadAlerters_UnknownNameReturnsError(t *testing.T) {
+	if _, err := LoadAlerters([]NotifierConfig{{Name: "does-not-exist"}}); err == nil {
+		t.Fatalf("expected an error for an unregistered alerter name")
+	}
+}
@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// SupportsResolve is implemented by Alerters that render a recovered check
+// differently from a plain message (PagerDuty's resolve event, Slack's
+// green attachment). The NotificationRouter passes these the original
+// LevelOK Alert; alerters without it get a synthesized "RECOVERED" Alert.
+type SupportsResolve interface {
+	SupportsResolve() bool
+}
+
+// incident tracks the state of one (container, check) pair between calls
+// to NotificationRouter.Notify.
+type incident struct {
+	level     Level
+	since     time.Time
+	lastSent  time.Time
+	escalated bool
+}
+
+// NotificationRouter sits between the monitor loop and the configured
+// Alerters. It tracks state per (container, check) so each Alerter only
+// has to implement Alert() — the router fires alerts on state transitions,
+// suppresses repeats within RepeatInterval, escalates after EscalateAfter,
+// and emits a recovery alert when a check flips back to ok.
+type NotificationRouter struct {
+	Alerters       []Alerter
+	RepeatInterval time.Duration
+	EscalateAfter  time.Duration
+
+	mu    sync.Mutex
+	state map[string]*incident
+}
+
+// NewNotificationRouter builds a NotificationRouter over the given Alerters.
+func NewNotificationRouter(alerters []Alerter, repeatInterval, escalateAfter time.Duration) *NotificationRouter {
+	return &NotificationRouter{
+		Alerters:       alerters,
+		RepeatInterval: repeatInterval,
+		EscalateAfter:  escalateAfter,
+		state:          map[string]*incident{},
+	}
+}
+
+func incidentKey(a *Alert) string {
+	return a.Container + ":" + a.Check
+}
+
+// Notify is called once per check result. It decides, based on the
+// tracked state for a.Container/a.Check, whether to suppress the result,
+// escalate it, or fan it out to every configured Alerter. A genuine rise
+// in severity (e.g. warn -> critical) always bypasses RepeatInterval
+// suppression and fires immediately, the same as a LevelOK recovery
+// would, regardless of the EscalateAfter timer.
+func (r *NotificationRouter) Notify(a *Alert) error {
+	key := incidentKey(a)
+	now := a.Time
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	r.mu.Lock()
+	cur, tracked := r.state[key]
+
+	if a.Level == LevelOK {
+		if !tracked {
+			r.mu.Unlock()
+			return nil // already ok, nothing to report
+		}
+		duration := now.Sub(cur.since)
+		delete(r.state, key)
+		r.mu.Unlock()
+		return r.fanOutRecovered(a, duration)
+	}
+
+	if !tracked {
+		r.state[key] = &incident{level: a.Level, since: now, lastSent: now}
+		r.mu.Unlock()
+		return r.fanOut(a)
+	}
+
+	worsened := severityLevels[a.Level] > severityLevels[cur.level]
+	escalate := !cur.escalated && r.EscalateAfter > 0 && now.Sub(cur.since) >= r.EscalateAfter
+	if now.Sub(cur.lastSent) < r.RepeatInterval && !escalate && !worsened {
+		r.mu.Unlock()
+		return nil // suppressed repeat
+	}
+
+	cur.level = a.Level
+	cur.lastSent = now
+	if escalate {
+		cur.escalated = true
+	}
+	r.mu.Unlock()
+
+	if escalate {
+		// Copy before mutating level so we don't surprise the caller by
+		// rewriting severity on the Alert they passed in.
+		escalated := *a
+		escalated.Level = LevelCritical
+		return r.fanOut(&escalated)
+	}
+
+	return r.fanOut(a)
+}
+
+// Close closes every configured Alerter, collecting any errors.
+func (r *NotificationRouter) Close() error {
+	var errs []string
+	for _, a := range r.Alerters {
+		if err := a.Close(); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// fanOut delivers a to every configured Alerter concurrently, so a slow or
+// rate-limited one (e.g. PagerDuty throttling its own send) doesn't delay
+// delivery to the others or block the caller any longer than the slowest
+// single Alerter.
+func (r *NotificationRouter) fanOut(a *Alert) error {
+	return r.dispatch(func(alerter Alerter) error {
+		return alerter.Alert(a)
+	})
+}
+
+func (r *NotificationRouter) fanOutRecovered(a *Alert, duration time.Duration) error {
+	return r.dispatch(func(alerter Alerter) error {
+		if sr, ok := alerter.(SupportsResolve); ok && sr.SupportsResolve() {
+			return alerter.Alert(a)
+		}
+
+		recovered := *a
+		recovered.Recovered = true
+		recovered.Duration = duration
+		recovered.SubjectAddendums = append([]string{"RECOVERED"}, a.SubjectAddendums...)
+
+		return alerter.Alert(&recovered)
+	})
+}
+
+// dispatch runs send against every configured Alerter concurrently and
+// collects any errors.
+func (r *NotificationRouter) dispatch(send func(Alerter) error) error {
+	var mu sync.Mutex
+	var errs []string
+	var wg sync.WaitGroup
+
+	for _, alerter := range r.Alerters {
+		wg.Add(1)
+		go func(alerter Alerter) {
+			defer wg.Done()
+			if err := send(alerter); err != nil {
+				mu.Lock()
+				errs = append(errs, err.Error())
+				mu.Unlock()
+			}
+		}(alerter)
+	}
+
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,232 @@
+package cmd
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingAlerter records every Alert it receives so tests can assert on
+// NotificationRouter's fan-out decisions without a real transport. delay,
+// if set, simulates a slow Alerter (e.g. PagerDuty throttling) to exercise
+// fanOut's concurrent dispatch.
+type recordingAlerter struct {
+	mu              sync.Mutex
+	alerts          []*Alert
+	supportsResolve bool
+	delay           time.Duration
+}
+
+func (r *recordingAlerter) Valid() error { return nil }
+func (r *recordingAlerter) Close() error { return nil }
+func (r *recordingAlerter) SupportsResolve() bool {
+	return r.supportsResolve
+}
+func (r *recordingAlerter) Alert(a *Alert) error {
+	if r.delay > 0 {
+		time.Sleep(r.delay)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.alerts = append(r.alerts, a)
+	return nil
+}
+
+func TestNotificationRouter_FirstBadAlertFires(t *testing.T) {
+	rec := &recordingAlerter{}
+	router := NewNotificationRouter([]Alerter{rec}, time.Hour, 0)
+
+	now := time.Now()
+	if err := router.Notify(&Alert{Container: "web", Check: "cpu", Level: LevelWarn, Time: now}); err != nil {
+		t.Fatalf("Notify returned error: %s", err)
+	}
+
+	if len(rec.alerts) != 1 {
+		t.Fatalf("expected 1 alert fired, got %d", len(rec.alerts))
+	}
+}
+
+func TestNotificationRouter_SuppressesRepeatsWithinInterval(t *testing.T) {
+	rec := &recordingAlerter{}
+	router := NewNotificationRouter([]Alerter{rec}, time.Hour, 0)
+
+	base := time.Now()
+	a := &Alert{Container: "web", Check: "cpu", Level: LevelWarn, Time: base}
+	router.Notify(a)
+	router.Notify(&Alert{Container: "web", Check: "cpu", Level: LevelWarn, Time: base.Add(time.Minute)})
+
+	if len(rec.alerts) != 1 {
+		t.Fatalf("expected repeat within RepeatInterval to be suppressed, got %d alerts", len(rec.alerts))
+	}
+}
+
+func TestNotificationRouter_RepeatsAfterIntervalElapses(t *testing.T) {
+	rec := &recordingAlerter{}
+	router := NewNotificationRouter([]Alerter{rec}, time.Minute, 0)
+
+	base := time.Now()
+	router.Notify(&Alert{Container: "web", Check: "cpu", Level: LevelWarn, Time: base})
+	router.Notify(&Alert{Container: "web", Check: "cpu", Level: LevelWarn, Time: base.Add(2 * time.Minute)})
+
+	if len(rec.alerts) != 2 {
+		t.Fatalf("expected repeat after RepeatInterval to fire, got %d alerts", len(rec.alerts))
+	}
+}
+
+func TestNotificationRouter_EscalatesWithoutMutatingCallersAlert(t *testing.T) {
+	rec := &recordingAlerter{}
+	router := NewNotificationRouter([]Alerter{rec}, 0, time.Minute)
+
+	base := time.Now()
+	first := &Alert{Container: "web", Check: "cpu", Level: LevelWarn, Time: base}
+	second := &Alert{Container: "web", Check: "cpu", Level: LevelWarn, Time: base.Add(2 * time.Minute)}
+	router.Notify(first)
+	router.Notify(second)
+
+	if first.Level != LevelWarn {
+		t.Fatalf("escalation must not mutate a previous caller's Alert, got Level=%s", first.Level)
+	}
+	if second.Level != LevelWarn {
+		t.Fatalf("escalation must not mutate the caller's own Alert in place, got Level=%s", second.Level)
+	}
+
+	if len(rec.alerts) != 2 {
+		t.Fatalf("expected 2 alerts fired, got %d", len(rec.alerts))
+	}
+
+	if rec.alerts[1].Level != LevelCritical {
+		t.Fatalf("expected escalated alert to carry LevelCritical, got %s", rec.alerts[1].Level)
+	}
+}
+
+func TestNotificationRouter_SeverityIncreaseBypassesRepeatSuppression(t *testing.T) {
+	rec := &recordingAlerter{}
+	router := NewNotificationRouter([]Alerter{rec}, time.Hour, 0)
+
+	base := time.Now()
+	router.Notify(&Alert{Container: "web", Check: "cpu", Level: LevelWarn, Time: base})
+	router.Notify(&Alert{Container: "web", Check: "cpu", Level: LevelCritical, Time: base.Add(time.Minute)})
+
+	if len(rec.alerts) != 2 {
+		t.Fatalf("expected a genuine severity increase to bypass RepeatInterval suppression, got %d alerts", len(rec.alerts))
+	}
+	if rec.alerts[1].Level != LevelCritical {
+		t.Fatalf("expected the worsened alert to carry its real Level (critical), got %s", rec.alerts[1].Level)
+	}
+}
+
+func TestNotificationRouter_SameSeverityRepeatStillSuppressed(t *testing.T) {
+	rec := &recordingAlerter{}
+	router := NewNotificationRouter([]Alerter{rec}, time.Hour, 0)
+
+	base := time.Now()
+	router.Notify(&Alert{Container: "web", Check: "cpu", Level: LevelWarn, Time: base})
+	router.Notify(&Alert{Container: "web", Check: "cpu", Level: LevelWarn, Time: base.Add(time.Minute)})
+
+	if len(rec.alerts) != 1 {
+		t.Fatalf("expected an unchanged severity to still be suppressed within RepeatInterval, got %d alerts", len(rec.alerts))
+	}
+}
+
+func TestNotificationRouter_FanOutDispatchesAlertersConcurrently(t *testing.T) {
+	const delay = 50 * time.Millisecond
+	slow := &recordingAlerter{delay: delay}
+	fast := &recordingAlerter{}
+	router := NewNotificationRouter([]Alerter{slow, fast}, time.Hour, 0)
+
+	start := time.Now()
+	if err := router.Notify(&Alert{Container: "web", Check: "cpu", Level: LevelWarn, Time: time.Now()}); err != nil {
+		t.Fatalf("Notify returned error: %s", err)
+	}
+	elapsed := time.Since(start)
+
+	if elapsed >= 2*delay {
+		t.Fatalf("expected alerters to be dispatched concurrently (~%s), took %s for two alerters", delay, elapsed)
+	}
+	if len(slow.alerts) != 1 || len(fast.alerts) != 1 {
+		t.Fatalf("expected both alerters to receive the alert, got slow=%d fast=%d", len(slow.alerts), len(fast.alerts))
+	}
+}
+
+func TestNotificationRouter_RecoveryIsSuppressedWhenNeverBad(t *testing.T) {
+	rec := &recordingAlerter{}
+	router := NewNotificationRouter([]Alerter{rec}, time.Hour, 0)
+
+	if err := router.Notify(&Alert{Container: "web", Check: "cpu", Level: LevelOK, Time: time.Now()}); err != nil {
+		t.Fatalf("Notify returned error: %s", err)
+	}
+
+	if len(rec.alerts) != 0 {
+		t.Fatalf("expected no alert for an already-ok check, got %d", len(rec.alerts))
+	}
+}
+
+func TestNotificationRouter_RecoverySynthesizesRecoveredAlertForPlainAlerters(t *testing.T) {
+	rec := &recordingAlerter{}
+	router := NewNotificationRouter([]Alerter{rec}, time.Hour, 0)
+
+	base := time.Now()
+	router.Notify(&Alert{Container: "web", Check: "cpu", Level: LevelCritical, Time: base})
+	router.Notify(&Alert{Container: "web", Check: "cpu", Level: LevelOK, Time: base.Add(5 * time.Minute)})
+
+	if len(rec.alerts) != 2 {
+		t.Fatalf("expected trigger + recovery, got %d alerts", len(rec.alerts))
+	}
+
+	recovered := rec.alerts[1]
+	if !recovered.Recovered {
+		t.Fatalf("expected synthesized recovery alert to have Recovered=true")
+	}
+	if recovered.Duration != 5*time.Minute {
+		t.Fatalf("expected recovery Duration of 5m, got %s", recovered.Duration)
+	}
+}
+
+func TestNotificationRouter_RecoveryPassesOriginalAlertWhenAlerterSupportsResolve(t *testing.T) {
+	rec := &recordingAlerter{supportsResolve: true}
+	router := NewNotificationRouter([]Alerter{rec}, time.Hour, 0)
+
+	base := time.Now()
+	router.Notify(&Alert{Container: "web", Check: "cpu", Level: LevelCritical, Time: base})
+	router.Notify(&Alert{Container: "web", Check: "cpu", Level: LevelOK, Time: base.Add(time.Minute)})
+
+	recovered := rec.alerts[1]
+	if recovered.Recovered {
+		t.Fatalf("expected a SupportsResolve alerter to get the original LevelOK Alert, not a synthesized one")
+	}
+	if recovered.Level != LevelOK {
+		t.Fatalf("expected Level to be LevelOK, got %s", recovered.Level)
+	}
+}
+
+func TestSeverityFilter_PassesRecoveryThroughRegardlessOfMinSeverity(t *testing.T) {
+	rec := &recordingAlerter{}
+	filtered := severityFilter{Alerter: rec, min: LevelCritical}
+
+	if err := filtered.Alert(&Alert{Level: LevelOK}); err != nil {
+		t.Fatalf("Alert returned error: %s", err)
+	}
+	if err := filtered.Alert(&Alert{Level: LevelWarn, Recovered: true}); err != nil {
+		t.Fatalf("Alert returned error: %s", err)
+	}
+	if err := filtered.Alert(&Alert{Level: LevelWarn}); err != nil {
+		t.Fatalf("Alert returned error: %s", err)
+	}
+
+	if len(rec.alerts) != 2 {
+		t.Fatalf("expected the two recovery alerts to pass and the plain warn to be filtered, got %d alerts", len(rec.alerts))
+	}
+}
+
+func TestSeverityFilter_ForwardsSupportsResolve(t *testing.T) {
+	rec := &recordingAlerter{supportsResolve: true}
+	filtered := severityFilter{Alerter: rec, min: LevelCritical}
+
+	sr, ok := Alerter(filtered).(SupportsResolve)
+	if !ok {
+		t.Fatalf("expected severityFilter to implement SupportsResolve")
+	}
+	if !sr.SupportsResolve() {
+		t.Fatalf("expected severityFilter.SupportsResolve() to delegate to the wrapped Alerter")
+	}
+}
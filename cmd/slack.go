@@ -0,0 +1,197 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"reflect"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+)
+
+// Slack contains all the info needed to post a Block Kit message to a slack
+// channel via an incoming webhook.
+type Slack struct {
+	WebhookURL string
+	Channel    string
+	Username   string
+	IconEmoji  string
+	IconURL    string
+	// Template, if set, is a text/template string executed with the
+	// triggered Alert as its data and sent as the raw JSON payload body,
+	// overriding the built-in Block Kit rendering below.
+	Template string
+}
+
+// Valid returns an error if slack settings are invalid
+func (s Slack) Valid() error {
+	errString := []string{}
+
+	if reflect.DeepEqual(Slack{}, s) {
+		return nil // assume that slack was omitted
+	}
+
+	if s.WebhookURL == "" {
+		errString = append(errString, ErrSlackNoWebHookURL.Error())
+	}
+
+	if s.Template != "" {
+		if _, err := template.New("slack").Parse(s.Template); err != nil {
+			errString = append(errString, fmt.Sprintf("invalid template: %s", err))
+		}
+	}
+
+	if len(errString) == 0 {
+		return nil
+	}
+
+	delimErr := strings.Join(errString, ", ")
+	err := errors.New(delimErr)
+
+	return errors.Wrap(err, "slack settings validation fail")
+}
+
+// Close releases any resources held by the Slack alerter. Slack posts over
+// plain http.Post today, so there is nothing to release.
+func (s Slack) Close() error {
+	return nil
+}
+
+// SupportsResolve reports that Slack renders recovery natively: the
+// NotificationRouter passes it the original Alert (green attachment)
+// instead of synthesizing a plain "RECOVERED" message.
+func (s Slack) SupportsResolve() bool {
+	return true
+}
+
+// Alert sends the alert to a slack channel as a Block Kit message.
+func (s Slack) Alert(a *Alert) error {
+	body, err := s.render(a)
+	if err != nil {
+		return errors.Wrap(err, "rendering slack payload")
+	}
+
+	resp, err := http.Post(s.WebhookURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "posting to slack")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := ioutil.ReadAll(resp.Body)
+		return errors.Errorf("slack returned %s: %s", resp.Status, respBody)
+	}
+
+	log.Println("sent alert to slack")
+	return nil
+}
+
+func (s Slack) render(a *Alert) ([]byte, error) {
+	if s.Template != "" {
+		tmpl, err := template.New("slack").Parse(s.Template)
+		if err != nil {
+			return nil, err
+		}
+
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, a); err != nil {
+			return nil, err
+		}
+
+		return buf.Bytes(), nil
+	}
+
+	return json.Marshal(s.blockKitMessage(a))
+}
+
+// slackMessage mirrors the subset of Slack's incoming webhook payload
+// schema that docker-alertd needs: a header, one section per check, and an
+// attachment colored by severity.
+type slackMessage struct {
+	Channel     string            `json:"channel,omitempty"`
+	Username    string            `json:"username,omitempty"`
+	IconEmoji   string            `json:"icon_emoji,omitempty"`
+	IconURL     string            `json:"icon_url,omitempty"`
+	Blocks      []slackBlock      `json:"blocks"`
+	Attachments []slackAttachment `json:"attachments,omitempty"`
+}
+
+type slackBlock struct {
+	Type string      `json:"type"`
+	Text *slackText  `json:"text,omitempty"`
+	// Fields renders the observed/threshold pair side-by-side in a section
+	// block, the same structured layout Argo's notifications-engine uses
+	// instead of mashing both values into the text line.
+	Fields []slackText `json:"fields,omitempty"`
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type slackAttachment struct {
+	Color  string       `json:"color"`
+	Blocks []slackBlock `json:"blocks"`
+}
+
+func (s Slack) blockKitMessage(a *Alert) slackMessage {
+	headerText := fmt.Sprintf("docker-alertd: %s", a.Container)
+	if a.Level == LevelOK {
+		headerText = fmt.Sprintf("docker-alertd: %s recovered", a.Container)
+	}
+
+	header := slackBlock{
+		Type: "header",
+		Text: &slackText{Type: "plain_text", Text: headerText},
+	}
+
+	section := slackBlock{Type: "section"}
+
+	if a.Level == LevelOK {
+		section.Text = &slackText{
+			Type: "mrkdwn",
+			Text: fmt.Sprintf("*%s*\n%s recovered", a.Check, a.Container),
+		}
+	} else {
+		section.Text = &slackText{
+			Type: "mrkdwn",
+			Text: fmt.Sprintf("*%s*\n%s", a.Check, a.Container),
+		}
+		section.Fields = []slackText{
+			{Type: "mrkdwn", Text: fmt.Sprintf("*Observed*\n%s", a.Value)},
+			{Type: "mrkdwn", Text: fmt.Sprintf("*Threshold*\n%s", a.Threshold)},
+		}
+	}
+
+	return slackMessage{
+		Channel:   s.Channel,
+		Username:  s.Username,
+		IconEmoji: s.IconEmoji,
+		IconURL:   s.IconURL,
+		Blocks:    []slackBlock{header},
+		Attachments: []slackAttachment{
+			{
+				Color:  slackColor(a.Level),
+				Blocks: []slackBlock{section},
+			},
+		},
+	}
+}
+
+// slackColor maps an Alert's severity to a Block Kit attachment color.
+func slackColor(l Level) string {
+	switch l {
+	case LevelCritical:
+		return "#FF0000"
+	case LevelWarn:
+		return "#FFCC00"
+	default:
+		return "#36A64F"
+	}
+}
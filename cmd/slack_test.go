@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSlack_BlockKitMessageIncludesObservedAndThresholdFields(t *testing.T) {
+	s := Slack{WebhookURL: "http://example.com"}
+	msg := s.blockKitMessage(&Alert{Container: "web", Check: "cpu", Level: LevelCritical, Value: "95%", Threshold: "90%"})
+
+	section := msg.Attachments[0].Blocks[0]
+	if len(section.Fields) != 2 {
+		t.Fatalf("expected 2 fields (observed, threshold), got %d", len(section.Fields))
+	}
+	if section.Fields[0].Text != "*Observed*\n95%" {
+		t.Fatalf("unexpected observed field: %q", section.Fields[0].Text)
+	}
+	if section.Fields[1].Text != "*Threshold*\n90%" {
+		t.Fatalf("unexpected threshold field: %q", section.Fields[1].Text)
+	}
+}
+
+func TestSlack_BlockKitMessageOmitsFieldsOnRecovery(t *testing.T) {
+	s := Slack{WebhookURL: "http://example.com"}
+	msg := s.blockKitMessage(&Alert{Container: "web", Check: "cpu", Level: LevelOK})
+
+	section := msg.Attachments[0].Blocks[0]
+	if len(section.Fields) != 0 {
+		t.Fatalf("expected no fields on a recovery message, got %d", len(section.Fields))
+	}
+}
+
+func TestSlack_AlertPostsBlockKitJSON(t *testing.T) {
+	var received slackMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("decoding slack payload: %s", err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	s := Slack{WebhookURL: server.URL, Channel: "#alerts"}
+	if err := s.Alert(&Alert{Container: "web", Check: "cpu", Level: LevelWarn, Value: "80%", Threshold: "75%"}); err != nil {
+		t.Fatalf("Alert returned error: %s", err)
+	}
+
+	if received.Channel != "#alerts" {
+		t.Fatalf("expected channel to round-trip, got %q", received.Channel)
+	}
+	if len(received.Attachments) != 1 || len(received.Attachments[0].Blocks[0].Fields) != 2 {
+		t.Fatalf("expected the posted payload to carry the observed/threshold fields")
+	}
+}
+
+func TestSlack_AlertReturnsErrorOnNonOKStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	s := Slack{WebhookURL: server.URL}
+	if err := s.Alert(&Alert{Container: "web", Check: "cpu"}); err == nil {
+		t.Fatalf("expected an error for a non-200 response")
+	}
+}
+
+func TestSlack_ValidTreatsZeroValueAsOmitted(t *testing.T) {
+	if err := (Slack{}).Valid(); err != nil {
+		t.Fatalf("expected a zero-value Slack to validate as omitted, got %s", err)
+	}
+}
+
+func TestSlack_ValidRejectsMissingWebhookURL(t *testing.T) {
+	if err := (Slack{Username: "bot"}).Valid(); err == nil {
+		t.Fatalf("expected an error when WebhookURL is missing")
+	}
+}
+
+func TestSlack_ValidRejectsInvalidTemplate(t *testing.T) {
+	if err := (Slack{WebhookURL: "http://example.com", Template: "{{.Broken"}).Valid(); err == nil {
+		t.Fatalf("expected an error for an invalid template")
+	}
+}
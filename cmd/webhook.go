@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+)
+
+func init() {
+	RegisterAlerter("webhook", func(settings map[string]interface{}) (Alerter, error) {
+		w := Webhook{
+			URL:     settingString(settings, "url"),
+			Method:  settingString(settings, "method"),
+			Body:    settingString(settings, "body"),
+			Headers: map[string]string{},
+			client:  &http.Client{},
+		}
+
+		if headers, ok := settings["headers"].(map[string]interface{}); ok {
+			for k, v := range headers {
+				w.Headers[k] = fmt.Sprintf("%v", v)
+			}
+		}
+
+		return w, nil
+	})
+}
+
+// Webhook implements the Alerter interface by POSTing (or otherwise
+// requesting) an arbitrary URL with a body rendered from a text/template,
+// so users can hit Discord, Mattermost, Teams, Gotify, or any endpoint of
+// their own without a code change to docker-alertd.
+type Webhook struct {
+	URL     string
+	Method  string
+	Headers map[string]string
+	// Body is a text/template string executed with the triggered Alert as
+	// its data. If empty, a.Dump() is sent as a plain text body.
+	Body string
+
+	client *http.Client
+}
+
+// Valid returns an error if the webhook settings are invalid.
+func (w Webhook) Valid() error {
+	errString := []string{}
+
+	if reflect.DeepEqual(Webhook{}, w) {
+		return nil // assume that the webhook was omitted
+	}
+
+	if w.URL == "" {
+		errString = append(errString, "no URL configured")
+	}
+
+	if w.Body != "" {
+		if _, err := template.New("webhook").Parse(w.Body); err != nil {
+			errString = append(errString, fmt.Sprintf("invalid body template: %s", err))
+		}
+	}
+
+	if len(errString) == 0 {
+		return nil
+	}
+
+	delimErr := strings.Join(errString, ", ")
+	err := errors.New(delimErr)
+
+	return errors.Wrap(err, "webhook settings validation fail")
+}
+
+// Alert renders the configured template (or a.Dump() if none is set) and
+// sends it to the configured URL with the configured method and headers.
+func (w Webhook) Alert(a *Alert) error {
+	body, err := w.render(a)
+	if err != nil {
+		return errors.Wrap(err, "rendering webhook body")
+	}
+
+	method := w.Method
+	if method == "" {
+		method = http.MethodPost
+	}
+
+	req, err := http.NewRequest(method, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "building webhook request")
+	}
+
+	for k, v := range w.Headers {
+		req.Header.Set(k, v)
+	}
+
+	client := w.client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "sending webhook request")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := ioutil.ReadAll(resp.Body)
+		return errors.Errorf("webhook returned %s: %s", resp.Status, respBody)
+	}
+
+	return nil
+}
+
+// Close releases the Webhook's pooled HTTP client.
+func (w Webhook) Close() error {
+	if w.client != nil {
+		w.client.CloseIdleConnections()
+	}
+	return nil
+}
+
+func (w Webhook) render(a *Alert) ([]byte, error) {
+	if w.Body == "" {
+		return []byte(a.Dump()), nil
+	}
+
+	tmpl, err := template.New("webhook").Parse(w.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, a); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
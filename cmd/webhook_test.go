@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWebhook_AlertSendsRenderedBodyWithConfiguredMethodAndHeaders(t *testing.T) {
+	var gotMethod, gotBody, gotHeader string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		gotHeader = r.Header.Get("X-Custom")
+		body, _ := ioutil.ReadAll(r.Body)
+		gotBody = string(body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	w := Webhook{
+		URL:     server.URL,
+		Method:  http.MethodPut,
+		Headers: map[string]string{"X-Custom": "yes"},
+		Body:    "{{.Container}}/{{.Check}}",
+	}
+
+	if err := w.Alert(&Alert{Container: "web", Check: "cpu"}); err != nil {
+		t.Fatalf("Alert returned error: %s", err)
+	}
+
+	if gotMethod != http.MethodPut {
+		t.Fatalf("expected configured method PUT, got %s", gotMethod)
+	}
+	if gotHeader != "yes" {
+		t.Fatalf("expected configured header to be sent, got %q", gotHeader)
+	}
+	if gotBody != "web/cpu" {
+		t.Fatalf("expected rendered template body, got %q", gotBody)
+	}
+}
+
+func TestWebhook_AlertDefaultsToPostAndDumpWithoutTemplate(t *testing.T) {
+	var gotMethod, gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		body, _ := ioutil.ReadAll(r.Body)
+		gotBody = string(body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	wh := Webhook{URL: server.URL}
+	a := &Alert{Container: "web", Check: "cpu"}
+
+	if err := wh.Alert(a); err != nil {
+		t.Fatalf("Alert returned error: %s", err)
+	}
+
+	if gotMethod != http.MethodPost {
+		t.Fatalf("expected default method POST, got %s", gotMethod)
+	}
+	if gotBody != a.Dump() {
+		t.Fatalf("expected a.Dump() as the default body, got %q", gotBody)
+	}
+}
+
+func TestWebhook_AlertReturnsErrorOnNonSuccessStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	wh := Webhook{URL: server.URL}
+	if err := wh.Alert(&Alert{Container: "web", Check: "cpu"}); err == nil {
+		t.Fatalf("expected an error for a 500 response")
+	}
+}
+
+func TestWebhook_ValidTreatsZeroValueAsOmitted(t *testing.T) {
+	if err := (Webhook{}).Valid(); err != nil {
+		t.Fatalf("expected a zero-value Webhook to validate as omitted, got %s", err)
+	}
+}
+
+func TestWebhook_ValidRejectsInvalidBodyTemplate(t *testing.T) {
+	if err := (Webhook{URL: "http://example.com", Body: "{{.Broken"}).Valid(); err == nil {
+		t.Fatalf("expected an error for an invalid body template")
+	}
+}